@@ -0,0 +1,159 @@
+// Copyright 2017-present The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package media
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Type (also known as MIME type) is a two-part identifier for file formats
+// and format contents transmitted on the Internet.
+// For Hugo's use case, we use the top-level type name / subtype name + suffix.
+// One example would be application/svg+xml
+// If no suffix is provided, FirstSuffix and FullSuffix return the empty string.
+// See https://en.wikipedia.org/wiki/Media_type
+type Type struct {
+	MainType string `json:"mainType"` // i.e. text
+	SubType  string `json:"subType"`  // i.e. html
+
+	// Suffixes is the list of file suffixes associated with this type, in
+	// order of preference. The first entry is the one used when Hugo needs
+	// to pick a single suffix, e.g. for the default output file name.
+	Suffixes []string `json:"suffixes"`
+}
+
+// Type returns a string representing the main- and sub-type of a media type, i.e. "text/css".
+func (m Type) Type() string {
+	return m.MainType + "/" + m.SubType
+}
+
+// String returns a string representation of the media type.
+func (m Type) String() string {
+	return m.Type()
+}
+
+// FirstSuffix is the first suffix defined for this Type, or the empty string
+// if none is defined. This is what Hugo will use when writing a file for
+// this media type unless overridden by more specific configuration.
+func (m Type) FirstSuffix() string {
+	if len(m.Suffixes) == 0 {
+		return ""
+	}
+	return m.Suffixes[0]
+}
+
+// FullSuffix is the file suffix with a leading ".", e.g. ".html", using the
+// first defined suffix.
+func (m Type) FullSuffix() string {
+	suffix := m.FirstSuffix()
+	if suffix == "" {
+		return ""
+	}
+	return "." + suffix
+}
+
+var (
+	CalendarType = Type{MainType: "text", SubType: "calendar", Suffixes: []string{"ics"}}
+	CSSType      = Type{MainType: "text", SubType: "css", Suffixes: []string{"css"}}
+	CSVType      = Type{MainType: "text", SubType: "csv", Suffixes: []string{"csv"}}
+	HTMLType     = Type{MainType: "text", SubType: "html", Suffixes: []string{"html", "htm"}}
+	JSONType     = Type{MainType: "application", SubType: "json", Suffixes: []string{"json"}}
+	RSSType      = Type{MainType: "application", SubType: "rss", Suffixes: []string{"xml"}}
+	SVGType      = Type{MainType: "image", SubType: "svg+xml", Suffixes: []string{"svg"}}
+	TextType     = Type{MainType: "text", SubType: "plain", Suffixes: []string{"txt"}}
+	XMLType      = Type{MainType: "application", SubType: "xml", Suffixes: []string{"xml"}}
+)
+
+// DefaultTypes is the default set of media types supported by Hugo.
+var DefaultTypes = Types{
+	CalendarType,
+	CSSType,
+	CSVType,
+	HTMLType,
+	JSONType,
+	RSSType,
+	SVGType,
+	TextType,
+	XMLType,
+}
+
+func init() {
+	sort.Sort(DefaultTypes)
+}
+
+// Types is a slice of media types.
+type Types []Type
+
+func (t Types) Len() int      { return len(t) }
+func (t Types) Swap(i, j int) { t[i], t[j] = t[j], t[i] }
+func (t Types) Less(i, j int) bool {
+	return t[i].Type() < t[j].Type()
+}
+
+// GetByType returns the media type with the given identifier, e.g. "text/css".
+func (t Types) GetByType(tp string) (Type, bool) {
+	for _, tt := range t {
+		if strings.EqualFold(tt.Type(), tp) {
+			return tt, true
+		}
+	}
+	return Type{}, false
+}
+
+// GetBySuffix gets a media type given a suffix, e.g. "html". It will return
+// false if no format could be found, or if the suffix given is ambiguous,
+// i.e. it maps to more than one media type. The lookup is case insensitive.
+func (t Types) GetBySuffix(suffix string) (tp Type, found bool) {
+	for _, tt := range t {
+		if tt.hasSuffix(suffix) {
+			if found {
+				// ambiguous
+				found = false
+				return Type{}, false
+			}
+			tp = tt
+			found = true
+		}
+	}
+	return
+}
+
+func (m Type) hasSuffix(suffix string) bool {
+	for _, s := range m.Suffixes {
+		if strings.EqualFold(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// FromStringAndExt creates a new Type from a MIME string with the suffix.
+func FromStringAndExt(t, suffix string) (Type, error) {
+	tp, err := fromString(t)
+	if err != nil {
+		return tp, err
+	}
+	tp.Suffixes = []string{strings.TrimPrefix(suffix, ".")}
+	return tp, nil
+}
+
+func fromString(t string) (Type, error) {
+	parts := strings.Split(t, "/")
+	if len(parts) != 2 {
+		return Type{}, fmt.Errorf("cannot parse media type %q", t)
+	}
+	return Type{MainType: parts[0], SubType: parts[1]}, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2017-present The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package media
+
+import "testing"
+
+func TestTypeFirstAndFullSuffix(t *testing.T) {
+	yaml := Type{MainType: "text", SubType: "yaml", Suffixes: []string{"yaml", "yml"}}
+	if got := yaml.FirstSuffix(); got != "yaml" {
+		t.Errorf("FirstSuffix() = %q, want %q", got, "yaml")
+	}
+	if got := yaml.FullSuffix(); got != ".yaml" {
+		t.Errorf("FullSuffix() = %q, want %q", got, ".yaml")
+	}
+
+	empty := Type{MainType: "application", SubType: "x-custom"}
+	if got := empty.FirstSuffix(); got != "" {
+		t.Errorf("FirstSuffix() = %q, want empty", got)
+	}
+	if got := empty.FullSuffix(); got != "" {
+		t.Errorf("FullSuffix() = %q, want empty", got)
+	}
+}
+
+func TestTypesGetBySuffix(t *testing.T) {
+	yaml := Type{MainType: "text", SubType: "yaml", Suffixes: []string{"yaml", "yml"}}
+	types := Types{HTMLType, yaml}
+
+	tp, found := types.GetBySuffix("YML")
+	if !found {
+		t.Fatal("expected to find type by case-insensitive alternate suffix")
+	}
+	if tp.SubType != "yaml" {
+		t.Errorf("got SubType %q, want %q", tp.SubType, "yaml")
+	}
+
+	if tp, found := types.GetBySuffix("htm"); !found || tp.SubType != "html" {
+		t.Errorf("got %v, %v; want the html type via its alternate suffix", tp, found)
+	}
+}
+
+func TestTypesGetBySuffixAmbiguous(t *testing.T) {
+	a := Type{MainType: "text", SubType: "a", Suffixes: []string{"dat"}}
+	b := Type{MainType: "text", SubType: "b", Suffixes: []string{"dat"}}
+	types := Types{a, b}
+
+	if _, found := types.GetBySuffix("dat"); found {
+		t.Error("expected ambiguous suffix match across two types to return not found")
+	}
+}
@@ -16,6 +16,7 @@ package output
 import (
 	"encoding/json"
 	"fmt"
+	"log"
 	"sort"
 	"strings"
 
@@ -63,6 +64,27 @@ type Format struct {
 
 	// Enable to ignore the global uglyURLs setting.
 	NoUgly bool
+
+	// PermalinkTemplate, when set, overrides how the target path for this
+	// format is built, e.g. "/:section/:slug/index.:outputFormat" so a
+	// format can be published at a different path than what Path/BaseName
+	// would otherwise produce (e.g. a JSON index at /section/index.json
+	// while HTML lives at /section/). It supports the same placeholders as
+	// the site's permalinks configuration, plus :outputFormat (the
+	// format's name, lower-cased) and :suffix.
+	PermalinkTemplate string
+
+	// IsStreaming, when true, tells the renderer to write this format's
+	// output incrementally via NewWriter instead of buffering the fully
+	// rendered content in memory. This matters for formats that can grow
+	// very large on big sites, e.g. a sitemap, a JSON search index or a
+	// CSV export.
+	IsStreaming bool
+
+	// NewWriter creates the Writer used to stream this format's output
+	// when IsStreaming is true. It is nil for the built-in buffered
+	// formats.
+	NewWriter WriterFactory `json:"-"`
 }
 
 var (
@@ -149,18 +171,21 @@ func (f Formats) Less(i, j int) bool { return f[i].Name < f[j].Name }
 
 // GetBySuffix gets a output format given as suffix, e.g. "html".
 // It will return false if no format could be found, or if the suffix given
-// is ambiguous.
+// is ambiguous, i.e. it matches more than one format.
 // The lookup is case insensitive.
 func (formats Formats) GetBySuffix(suffix string) (f Format, found bool) {
 	for _, ff := range formats {
-		if strings.EqualFold(suffix, ff.MediaType.Suffix) {
-			if found {
-				// ambiguous
-				found = false
-				return
+		for _, s := range ff.MediaType.Suffixes {
+			if strings.EqualFold(suffix, s) {
+				if found {
+					// ambiguous
+					found = false
+					return Format{}, false
+				}
+				f = ff
+				found = true
+				break
 			}
-			f = ff
-			found = true
 		}
 	}
 	return
@@ -223,8 +248,23 @@ func DecodeFormats(mediaTypes media.Types, maps ...map[string]interface{}) (Form
 	f := make(Formats, len(DefaultFormats))
 	copy(f, DefaultFormats)
 
+	// Formats registered via Register/MustRegister take precedence over the
+	// built-in defaults, but are themselves the lowest priority relative to
+	// anything given in maps.
+	for _, r := range registered() {
+		f = f.Replace(r.Name, r)
+	}
+
 	for _, m := range maps {
 		for k, v := range m {
+			// The legacy singular "suffix" key is deprecated in favour of
+			// "suffixes", which allows a media type to map to more than
+			// one file extension. Rewrite it before decoding so existing
+			// site configurations keep working.
+			if vm, ok := v.(map[string]interface{}); ok {
+				normalizeSuffixesInConfig(vm)
+			}
+
 			found := false
 			for i, vv := range f {
 				if strings.EqualFold(k, vv.Name) {
@@ -232,6 +272,10 @@ func DecodeFormats(mediaTypes media.Types, maps ...map[string]interface{}) (Form
 					if err := decode(mediaTypes, v, &f[i]); err != nil {
 						return f, err
 					}
+					applyConfiguredSuffixes(v, &f[i])
+					if err := validatePermalinkTemplate(f[i].PermalinkTemplate); err != nil {
+						return f, err
+					}
 					found = true
 				}
 			}
@@ -241,6 +285,7 @@ func DecodeFormats(mediaTypes media.Types, maps ...map[string]interface{}) (Form
 				if err := decode(mediaTypes, v, &newOutFormat); err != nil {
 					return f, err
 				}
+				applyConfiguredSuffixes(v, &newOutFormat)
 
 				// We need values for these
 				if newOutFormat.BaseName == "" {
@@ -249,6 +294,9 @@ func DecodeFormats(mediaTypes media.Types, maps ...map[string]interface{}) (Form
 				if newOutFormat.Rel == "" {
 					newOutFormat.Rel = "alternate"
 				}
+				if err := validatePermalinkTemplate(newOutFormat.PermalinkTemplate); err != nil {
+					return f, err
+				}
 
 				f = append(f, newOutFormat)
 			}
@@ -260,6 +308,71 @@ func DecodeFormats(mediaTypes media.Types, maps ...map[string]interface{}) (Form
 	return f, nil
 }
 
+// normalizeSuffixesInConfig rewrites the legacy singular "suffix" key into
+// "suffixes" so old site configurations keep working. If "suffixes" is
+// already set, the legacy "suffix" key is dropped and "suffixes" is kept.
+func normalizeSuffixesInConfig(vm map[string]interface{}) {
+	for k, v := range vm {
+		if !strings.EqualFold(k, "suffix") {
+			continue
+		}
+
+		if _, found := getKeyFold(vm, "suffixes"); found {
+			// Both given; keep the new key and ignore the deprecated one.
+			delete(vm, k)
+			return
+		}
+
+		log.Printf("WARNING: the \"suffix\" output format option is deprecated and will be removed in a future release, use \"suffixes\" instead\n")
+
+		delete(vm, k)
+		vm["suffixes"] = []interface{}{v}
+		return
+	}
+}
+
+// applyConfiguredSuffixes re-applies any suffixes given in the raw output
+// format config to the media type of f. This is needed because the media
+// type looked up from its name (via mediaTypes.GetByType) carries its own
+// default suffixes, which a user should be able to override for a given
+// output format, e.g. to accept both "yaml" and "yml".
+func applyConfiguredSuffixes(v interface{}, f *Format) {
+	vm, ok := v.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	raw, found := getKeyFold(vm, "suffixes")
+	if !found {
+		return
+	}
+
+	var suffixes []string
+	switch vv := raw.(type) {
+	case []interface{}:
+		for _, s := range vv {
+			suffixes = append(suffixes, fmt.Sprint(s))
+		}
+	case []string:
+		suffixes = vv
+	case string:
+		suffixes = []string{vv}
+	}
+
+	if len(suffixes) > 0 {
+		f.MediaType.Suffixes = suffixes
+	}
+}
+
+func getKeyFold(vm map[string]interface{}, key string) (interface{}, bool) {
+	for k, v := range vm {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
 func decode(mediaTypes media.Types, input, output interface{}) error {
 	config := &mapstructure.DecoderConfig{
 		Metadata:         nil,
@@ -300,8 +413,15 @@ func decode(mediaTypes media.Types, input, output interface{}) error {
 	return decoder.Decode(input)
 }
 
+// BaseFilename returns the base filename of f, including its extension,
+// using the first of the media type's suffixes. If the media type has no
+// suffixes defined, the extension (and its separating ".") is omitted.
 func (f Format) BaseFilename() string {
-	return f.BaseName + "." + f.MediaType.Suffix
+	suffix := f.MediaType.FirstSuffix()
+	if suffix == "" {
+		return f.BaseName
+	}
+	return f.BaseName + "." + suffix
 }
 
 func (f Format) MarshalJSON() ([]byte, error) {
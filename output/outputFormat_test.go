@@ -0,0 +1,91 @@
+// Copyright 2017-present The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"testing"
+
+	"github.com/spf13/hugo/media"
+)
+
+func TestFormatsGetBySuffix(t *testing.T) {
+	formats := Formats{HTMLFormat, JSONFormat}
+
+	if _, found := formats.GetBySuffix("HTML"); !found {
+		t.Error("expected case-insensitive match for \"HTML\"")
+	}
+
+	if _, found := formats.GetBySuffix("xml"); found {
+		t.Error("expected no match for a suffix not declared by any format")
+	}
+}
+
+func TestFormatsFromFilename(t *testing.T) {
+	formats := Formats{AMPFormat, JSONFormat}
+
+	f, found := formats.FromFilename("mytemplate.amp.html")
+	if !found || f.Name != "AMP" {
+		t.Errorf("got %v, %v; want AMP format", f, found)
+	}
+
+	f, found = formats.FromFilename("mytemplate.json")
+	if !found || f.Name != "JSON" {
+		t.Errorf("got %v, %v; want JSON format", f, found)
+	}
+
+	if _, found := formats.FromFilename("mytemplate"); found {
+		t.Error("expected no match for a filename without a suffix")
+	}
+}
+
+func TestFormatBaseFilename(t *testing.T) {
+	if got := HTMLFormat.BaseFilename(); got != "index.html" {
+		t.Errorf("got %q, want %q", got, "index.html")
+	}
+
+	noSuffix := Format{
+		Name:      "Custom",
+		BaseName:  "index",
+		MediaType: media.Type{MainType: "application", SubType: "x-custom"},
+	}
+	if got := noSuffix.BaseFilename(); got != "index" {
+		t.Errorf("got %q, want %q (no trailing dot when suffixes are empty)", got, "index")
+	}
+}
+
+func TestDecodeFormatsLegacySuffixKey(t *testing.T) {
+	mediaTypes := media.Types{media.JSONType}
+
+	maps := []map[string]interface{}{
+		{
+			"json": map[string]interface{}{
+				"suffix": "jsonld",
+			},
+		},
+	}
+
+	formats, err := DecodeFormats(mediaTypes, maps...)
+	if err != nil {
+		t.Fatalf("DecodeFormats returned error: %s", err)
+	}
+
+	f, found := formats.GetByName("json")
+	if !found {
+		t.Fatal("expected to find the json format")
+	}
+
+	if f.MediaType.FirstSuffix() != "jsonld" {
+		t.Errorf("got suffix %q, want %q", f.MediaType.FirstSuffix(), "jsonld")
+	}
+}
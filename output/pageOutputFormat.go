@@ -0,0 +1,101 @@
+// Copyright 2017-present The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import "strings"
+
+// PagePermalinker is implemented by the page types that own a set of
+// OutputFormats (e.g. hugolib.Page). It lets this package resolve the
+// permalinks for a given Format without depending on the page/site
+// packages.
+type PagePermalinker interface {
+	OutputFormatPermalink(f Format) (permalink, relPermalink string)
+}
+
+// OutputFormat wraps a Format for a single page, resolving the Rel value
+// and the permalinks for that page in that format. The zero value of Rel
+// on Format is not enough on its own: whether a format is "canonical" or
+// "alternate" (or "amphtml") depends on which format the page is currently
+// being rendered in, not just the format's own default.
+//
+// This package has no Page type of its own to reference, so the owning
+// page itself is not stored here; NewOutputFormats only borrows a
+// PagePermalinker long enough to resolve Permalink/RelPermalink below.
+type OutputFormat struct {
+	Format
+
+	Permalink    string
+	RelPermalink string
+
+	isCanonical bool
+}
+
+// Rel returns the value to use in the rel attribute of a <link> tag for this
+// output format. It is "canonical" for the format currently being rendered,
+// and the format's own Rel (defaulting to "alternate") otherwise.
+func (o OutputFormat) Rel() string {
+	if o.isCanonical {
+		return "canonical"
+	}
+	if o.Format.Rel != "" {
+		return o.Format.Rel
+	}
+	return "alternate"
+}
+
+// OutputFormats holds the resolved OutputFormat values for a page, one per
+// output format the page is rendered in.
+type OutputFormats []OutputFormat
+
+// Get gets a OutputFormat given its name, e.g. "HTML".
+func (o OutputFormats) Get(name string) (OutputFormat, bool) {
+	for _, f := range o {
+		if strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+	return OutputFormat{}, false
+}
+
+// Alternative returns the OutputFormats that are not the canonical (current)
+// format, suitable for iterating over in a template to print e.g.
+// <link rel="alternate" ...> tags for a page's other representations.
+func (o OutputFormats) Alternative() OutputFormats {
+	var alt OutputFormats
+	for _, f := range o {
+		if !f.isCanonical {
+			alt = append(alt, f)
+		}
+	}
+	return alt
+}
+
+// NewOutputFormats resolves the OutputFormats for a page rendered in the
+// given formats, with current identifying which of those is the format
+// currently being rendered (and therefore canonical).
+func NewOutputFormats(formats Formats, current Format, pager PagePermalinker) OutputFormats {
+	ofs := make(OutputFormats, len(formats))
+
+	for i, f := range formats {
+		permalink, relPermalink := pager.OutputFormatPermalink(f)
+		ofs[i] = OutputFormat{
+			Format:       f,
+			Permalink:    permalink,
+			RelPermalink: relPermalink,
+			isCanonical:  strings.EqualFold(f.Name, current.Name),
+		}
+	}
+
+	return ofs
+}
@@ -0,0 +1,66 @@
+// Copyright 2017-present The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import "testing"
+
+type stubPermalinker struct{}
+
+func (stubPermalinker) OutputFormatPermalink(f Format) (string, string) {
+	return "https://example.org/page." + f.MediaType.FirstSuffix(), "/page." + f.MediaType.FirstSuffix()
+}
+
+func TestNewOutputFormatsRel(t *testing.T) {
+	formats := Formats{AMPFormat, HTMLFormat, JSONFormat}
+	ofs := NewOutputFormats(formats, HTMLFormat, stubPermalinker{})
+
+	html, found := ofs.Get("HTML")
+	if !found {
+		t.Fatal("expected to find the HTML format")
+	}
+	if got := html.Rel(); got != "canonical" {
+		t.Errorf("HTML Rel() = %q, want %q", got, "canonical")
+	}
+
+	amp, found := ofs.Get("AMP")
+	if !found {
+		t.Fatal("expected to find the AMP format")
+	}
+	if got := amp.Rel(); got != "amphtml" {
+		t.Errorf("AMP Rel() = %q, want %q", got, "amphtml")
+	}
+
+	json, found := ofs.Get("JSON")
+	if !found {
+		t.Fatal("expected to find the JSON format")
+	}
+	if got := json.Rel(); got != "alternate" {
+		t.Errorf("JSON Rel() = %q, want %q", got, "alternate")
+	}
+}
+
+func TestOutputFormatsAlternative(t *testing.T) {
+	formats := Formats{AMPFormat, HTMLFormat, JSONFormat}
+	ofs := NewOutputFormats(formats, HTMLFormat, stubPermalinker{})
+
+	alt := ofs.Alternative()
+	if len(alt) != 2 {
+		t.Fatalf("got %d alternative formats, want 2", len(alt))
+	}
+	for _, f := range alt {
+		if f.Name == "HTML" {
+			t.Error("the canonical HTML format should not appear in Alternative()")
+		}
+	}
+}
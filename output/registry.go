@@ -0,0 +1,101 @@
+// Copyright 2017-present The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = Formats{}
+)
+
+// Register adds f to the global registry of output formats, so that any
+// subsequent call to DecodeFormats will consider it alongside the built-in
+// DefaultFormats. This lets code that imports Hugo as a library (e.g. to
+// add a custom feed format such as JSON Feed or Atom) plug in a Format
+// without round-tripping it through a site configuration map.
+//
+// Register returns an error if a format with the same name is already
+// registered; use Deregister first if the intent is to replace it.
+func Register(f Format) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for _, r := range registry {
+		if strings.EqualFold(r.Name, f.Name) {
+			return fmt.Errorf("output format %q is already registered", f.Name)
+		}
+	}
+
+	registry = append(registry, f)
+	sort.Sort(registry)
+
+	return nil
+}
+
+// MustRegister is like Register but panics if f cannot be registered.
+func MustRegister(f Format) {
+	if err := Register(f); err != nil {
+		panic(err)
+	}
+}
+
+// Deregister removes the format with the given name from the global
+// registry, if present. It is a no-op if no such format is registered.
+// This is mainly useful in tests.
+func Deregister(name string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = registry.Remove(name)
+}
+
+// registered returns a copy of the currently registered formats.
+func registered() Formats {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	r := make(Formats, len(registry))
+	copy(r, registry)
+	return r
+}
+
+// Remove returns a copy of formats with the format with the given name, if
+// any, removed.
+func (formats Formats) Remove(name string) Formats {
+	out := make(Formats, 0, len(formats))
+	for _, f := range formats {
+		if !strings.EqualFold(f.Name, name) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Replace returns a copy of formats with the format with the given name
+// swapped for f. If no format with that name is found, f is appended.
+func (formats Formats) Replace(name string, f Format) Formats {
+	out := make(Formats, len(formats))
+	copy(out, formats)
+	for i, ff := range out {
+		if strings.EqualFold(ff.Name, name) {
+			out[i] = f
+			return out
+		}
+	}
+	return append(out, f)
+}
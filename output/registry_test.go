@@ -0,0 +1,93 @@
+// Copyright 2017-present The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/spf13/hugo/media"
+)
+
+func TestRegisterDuplicateName(t *testing.T) {
+	defer Deregister("JSONFeed")
+
+	f := Format{Name: "JSONFeed", MediaType: media.JSONType, BaseName: "feed"}
+	if err := Register(f); err != nil {
+		t.Fatalf("unexpected error on first Register: %s", err)
+	}
+
+	if err := Register(f); err == nil {
+		t.Fatal("expected an error registering a duplicate name")
+	}
+}
+
+func TestDeregister(t *testing.T) {
+	f := Format{Name: "Atom", MediaType: media.XMLType, BaseName: "atom"}
+	if err := Register(f); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	Deregister("Atom")
+
+	for _, r := range registered() {
+		if r.Name == "Atom" {
+			t.Fatal("expected Atom to be removed from the registry")
+		}
+	}
+}
+
+func TestRegisterConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("Concurrent%d", i)
+			if err := Register(Format{Name: name, MediaType: media.JSONType}); err != nil {
+				t.Errorf("unexpected error registering %s: %s", name, err)
+			}
+			Deregister(name)
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func TestFormatsRemoveAndReplace(t *testing.T) {
+	formats := Formats{AMPFormat, HTMLFormat, JSONFormat}
+
+	removed := formats.Remove("HTML")
+	if _, found := removed.GetByName("HTML"); found {
+		t.Error("expected HTML to be removed")
+	}
+	if len(removed) != len(formats)-1 {
+		t.Errorf("got %d formats, want %d", len(removed), len(formats)-1)
+	}
+
+	customJSON := JSONFormat
+	customJSON.BaseName = "search-index"
+	replaced := formats.Replace("JSON", customJSON)
+	f, found := replaced.GetByName("JSON")
+	if !found || f.BaseName != "search-index" {
+		t.Errorf("got %v, %v; want the replaced JSON format", f, found)
+	}
+
+	appended := formats.Replace("RSS", RSSFormat)
+	if _, found := appended.GetByName("RSS"); !found {
+		t.Error("expected Replace to append a format with a name not already present")
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2017-present The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import "io"
+
+// Writer is implemented by the value returned from a streaming Format's
+// NewWriter. Records are pushed one at a time via WriteRecord instead of
+// the renderer building the full output in memory before writing it, and
+// Close gives the format a chance to finalize any wrapping syntax (e.g. the
+// closing "]" of a JSON array).
+type Writer interface {
+	io.Writer
+
+	// WriteRecord writes a single record of the streamed output, e.g. one
+	// row of a CSV export or one document of a JSON search index.
+	WriteRecord(v interface{}) error
+
+	// Close finalizes the streamed output. It does not close dst.
+	Close() error
+}
+
+// WriterFactory creates the Writer used to stream a Format's output to dst.
+type WriterFactory func(dst io.Writer) (Writer, error)
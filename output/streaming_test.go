@@ -0,0 +1,78 @@
+// Copyright 2017-present The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+type fakeWriter struct {
+	io.Writer
+	records []interface{}
+	closed  bool
+}
+
+func (w *fakeWriter) WriteRecord(v interface{}) error {
+	w.records = append(w.records, v)
+	_, err := fmt.Fprintf(w.Writer, "%v\n", v)
+	return err
+}
+
+func (w *fakeWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestFormatNewWriter(t *testing.T) {
+	f := Format{
+		Name:        "NDJSON",
+		IsStreaming: true,
+		NewWriter: func(dst io.Writer) (Writer, error) {
+			return &fakeWriter{Writer: dst}, nil
+		},
+	}
+
+	if !f.IsStreaming {
+		t.Fatal("expected IsStreaming to be true")
+	}
+
+	var buf bytes.Buffer
+	w, err := f.NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := w.WriteRecord("one"); err != nil {
+		t.Fatalf("unexpected error writing record: %s", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error closing writer: %s", err)
+	}
+
+	if got := buf.String(); got != "one\n" {
+		t.Errorf("got %q, want %q", got, "one\n")
+	}
+}
+
+func TestFormatNotStreamingHasNilWriter(t *testing.T) {
+	if HTMLFormat.IsStreaming {
+		t.Error("HTMLFormat should not be a streaming format")
+	}
+	if HTMLFormat.NewWriter != nil {
+		t.Error("HTMLFormat should have no NewWriter factory")
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2017-present The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var placeholderRe = regexp.MustCompile(`:[A-Za-z]+`)
+
+// TargetPathDescriptor holds the page-specific values needed to expand a
+// Format's PermalinkTemplate. It mirrors the placeholders accepted by the
+// site's permalinks configuration.
+type TargetPathDescriptor struct {
+	Section string
+	Slug    string
+	Title   string
+
+	Year  int
+	Month int
+	Day   int
+}
+
+// placeholders returns the substitution values for d and f, keyed by
+// placeholder name (without the leading ":").
+func (d TargetPathDescriptor) placeholders(f Format) map[string]string {
+	return map[string]string{
+		"section":      d.Section,
+		"slug":         d.Slug,
+		"title":        d.Title,
+		"year":         strconv.Itoa(d.Year),
+		"month":        fmt.Sprintf("%02d", d.Month),
+		"day":          fmt.Sprintf("%02d", d.Day),
+		"outputformat": strings.ToLower(f.Name),
+		"suffix":       f.MediaType.FirstSuffix(),
+	}
+}
+
+// TargetPath returns the target path fragment for a page rendered in this
+// format, given d. When f has a PermalinkTemplate, the result is a full
+// path expanded from d (section, slug, date, etc.). Otherwise it falls
+// back to f.BaseFilename, a bare filename with no section/slug prefix;
+// callers are responsible for joining that filename onto the page's own
+// directory to get the full target path.
+func (f Format) TargetPath(d TargetPathDescriptor) (string, error) {
+	if f.PermalinkTemplate == "" {
+		return f.BaseFilename(), nil
+	}
+	return expandPermalinkTemplate(f.PermalinkTemplate, f, d)
+}
+
+// validPermalinkPlaceholders are the placeholders recognized in a
+// PermalinkTemplate, kept in sync with TargetPathDescriptor.placeholders.
+var validPermalinkPlaceholders = map[string]bool{
+	"section":      true,
+	"slug":         true,
+	"title":        true,
+	"year":         true,
+	"month":        true,
+	"day":          true,
+	"outputformat": true,
+	"suffix":       true,
+}
+
+// validatePermalinkTemplate reports an error if template contains a
+// placeholder that Hugo does not know how to expand.
+func validatePermalinkTemplate(template string) error {
+	for _, m := range placeholderRe.FindAllString(template, -1) {
+		name := strings.ToLower(strings.TrimPrefix(m, ":"))
+		if !validPermalinkPlaceholders[name] {
+			return fmt.Errorf("unrecognized placeholder %q in permalink template %q", m, template)
+		}
+	}
+	return nil
+}
+
+func expandPermalinkTemplate(template string, f Format, d TargetPathDescriptor) (string, error) {
+	if err := validatePermalinkTemplate(template); err != nil {
+		return "", err
+	}
+
+	placeholders := d.placeholders(f)
+
+	expanded := placeholderRe.ReplaceAllStringFunc(template, func(m string) string {
+		name := strings.ToLower(strings.TrimPrefix(m, ":"))
+		return placeholders[name]
+	})
+
+	return expanded, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2017-present The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import "testing"
+
+func TestFormatTargetPathNoTemplate(t *testing.T) {
+	got, err := HTMLFormat.TargetPath(TargetPathDescriptor{Section: "posts", Slug: "my-post"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != "index.html" {
+		t.Errorf("got %q, want %q", got, "index.html")
+	}
+}
+
+func TestFormatTargetPathWithTemplate(t *testing.T) {
+	f := JSONFormat
+	f.PermalinkTemplate = "/:section/index.:suffix"
+
+	got, err := f.TargetPath(TargetPathDescriptor{Section: "posts"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != "/posts/index.json" {
+		t.Errorf("got %q, want %q", got, "/posts/index.json")
+	}
+}
+
+func TestFormatTargetPathAllPlaceholders(t *testing.T) {
+	f := HTMLFormat
+	f.PermalinkTemplate = "/amp/:section/:year/:month/:day/:slug/index.:outputformat"
+
+	got, err := f.TargetPath(TargetPathDescriptor{
+		Section: "posts",
+		Slug:    "my-post",
+		Year:    2018,
+		Month:   1,
+		Day:     5,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := "/amp/posts/2018/01/05/my-post/index.html"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestValidatePermalinkTemplateInvalidPlaceholder(t *testing.T) {
+	err := validatePermalinkTemplate("/:section/:bogus/index.html")
+	if err == nil {
+		t.Fatal("expected an error for an unrecognized placeholder")
+	}
+}
+
+func TestValidatePermalinkTemplateValid(t *testing.T) {
+	if err := validatePermalinkTemplate("/:section/:slug/index.:suffix"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}